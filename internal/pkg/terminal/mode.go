@@ -0,0 +1,152 @@
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// Mode selects how the UI renders progress.
+type Mode int
+
+const (
+	// ModeInteractive draws the full-screen, alternate-screen UI. It is
+	// the default when the writer is a terminal.
+	ModeInteractive Mode = iota
+	// ModePlain prints one line per finished kind, for piping to a log
+	// file or CI system.
+	ModePlain
+	// ModeJSON emits newline-delimited JSON events, one per finished
+	// kind plus a final "done" event.
+	ModeJSON
+)
+
+// detectMode picks ModeInteractive when w is a terminal, ModePlain
+// otherwise. Callers can override the result with SetMode, e.g. from a
+// --output=json flag.
+func detectMode(w io.Writer) Mode {
+	if f, ok := w.(*os.File); ok {
+		if fi, err := f.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+			return ModeInteractive
+		}
+	}
+	return ModePlain
+}
+
+// SetMode forces the UI into the given mode, overriding the
+// auto-detection performed in NewUI.
+func (u *UI) SetMode(mode Mode) {
+	u.mode = mode
+}
+
+// ProgressPrinter renders progress updates for the non-interactive UI
+// modes (ModePlain, ModeJSON), so startReporting's loop doesn't need to
+// know the output format.
+type ProgressPrinter interface {
+	// KindDone is called once a kind has finished fetching.
+	KindDone(update *GetResourcesUpdate, processedKinds, totalKinds int)
+	// Finish is called once after the last kind, before Start returns.
+	Finish(totalResources int)
+}
+
+// textProgressPrinter implements ProgressPrinter for ModePlain.
+type textProgressPrinter struct {
+	ui *UI
+}
+
+func (p *textProgressPrinter) KindDone(update *GetResourcesUpdate, processedKinds, totalKinds int) {
+	p.ui.Printf("fetched %s: %d resources\n", update.Kind, update.Resources)
+	p.ui.flush()
+}
+
+func (p *textProgressPrinter) Finish(totalResources int) {
+	p.ui.Printf("done: %d resources\n", totalResources)
+	p.ui.flush()
+}
+
+// jsonProgressPrinter implements ProgressPrinter for ModeJSON.
+type jsonProgressPrinter struct {
+	ui *UI
+}
+
+type kindDoneEvent struct {
+	Event     string `json:"event"`
+	Kind      string `json:"kind"`
+	Resources int    `json:"resources"`
+	Processed int    `json:"processed"`
+	Total     int    `json:"total"`
+}
+
+type doneEvent struct {
+	Event     string `json:"event"`
+	Resources int    `json:"resources"`
+}
+
+func (p *jsonProgressPrinter) KindDone(update *GetResourcesUpdate, processedKinds, totalKinds int) {
+	p.emit(kindDoneEvent{
+		Event:     "kind_done",
+		Kind:      update.Kind,
+		Resources: update.Resources,
+		Processed: processedKinds,
+		Total:     totalKinds,
+	})
+}
+
+func (p *jsonProgressPrinter) Finish(totalResources int) {
+	p.emit(doneEvent{Event: "done", Resources: totalResources})
+}
+
+func (p *jsonProgressPrinter) emit(v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	p.ui.Println(string(b))
+	p.ui.flush()
+}
+
+// startReporting runs the shared ModePlain/ModeJSON loop: wait for the
+// kind count, then at each u.minUpdatePause tick drain every kind-done
+// event recorded since the last tick and print it through the selected
+// ProgressPrinter, agnostic of the output format. Draining the full
+// backlog (rather than only the latest event) ensures every kind gets
+// its own line/ndjson event even if several finish within one tick.
+func (u *UI) startReporting(ctx context.Context) {
+	defer u.flush()
+	var printer ProgressPrinter
+	if u.mode == ModeJSON {
+		printer = &jsonProgressPrinter{ui: u}
+	} else {
+		printer = &textProgressPrinter{ui: u}
+	}
+	select {
+	case <-ctx.Done():
+		return
+	case <-u.totalKindsSet:
+	}
+	totalKinds, _, _, _, _ := u.counter.snapshot()
+	ticker := time.NewTicker(u.minUpdatePause)
+	defer ticker.Stop()
+	var processedKinds int
+	report := func() {
+		for _, ev := range u.counter.drainPending() {
+			processedKinds++
+			printer.KindDone(&GetResourcesUpdate{Kind: ev.kind, Resources: ev.resources}, processedKinds, totalKinds)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-u.finished:
+			report()
+			_, _, totalResourcesFound, _, _ := u.counter.snapshot()
+			printer.Finish(totalResourcesFound)
+			return
+		case <-ticker.C:
+			report()
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStartReportingPlainModePrintsOneLinePerKind guards against the
+// atomic-counter refactor silently coalescing several kinds reported
+// within one tick into a single line.
+func TestStartReportingPlainModePrintsOneLinePerKind(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewUI(&noopBar{}, newFakeTermInfo(), &out)
+	ui.SetMinUpdatePause(time.Hour) // never tick on its own; Finish must still drain everything
+
+	ui.SetTotalKinds(5)
+	for i := 0; i < 5; i++ {
+		ui.ReportKindDone("kind"+strconv.Itoa(i), i+1)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ui.startReporting(context.Background())
+		close(done)
+	}()
+	ui.Finish()
+	<-done
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	fetched := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "fetched ") {
+			fetched++
+		}
+	}
+	if fetched != 5 {
+		t.Fatalf("expected 5 \"fetched\" lines, got %d in output:\n%s", fetched, out.String())
+	}
+	if last := lines[len(lines)-1]; !strings.HasPrefix(last, "done: ") {
+		t.Fatalf("expected a trailing \"done:\" line, got %q", last)
+	}
+}
+
+// TestStartReportingJSONModeEmitsOneEventPerKind is the ModeJSON
+// counterpart: every ReportKindDone call must produce its own
+// kind_done event, even when several land before the next drain.
+func TestStartReportingJSONModeEmitsOneEventPerKind(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewUI(&noopBar{}, newFakeTermInfo(), &out)
+	ui.SetMode(ModeJSON)
+	ui.SetMinUpdatePause(time.Hour)
+
+	ui.SetTotalKinds(5)
+	for i := 0; i < 5; i++ {
+		ui.ReportKindDone("kind"+strconv.Itoa(i), i+1)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ui.startReporting(context.Background())
+		close(done)
+	}()
+	ui.Finish()
+	<-done
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	kindDone := 0
+	sawDone := false
+	for _, line := range lines {
+		var event struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", line, err)
+		}
+		switch event.Event {
+		case "kind_done":
+			kindDone++
+		case "done":
+			sawDone = true
+		}
+	}
+	if kindDone != 5 {
+		t.Fatalf("expected 5 kind_done events, got %d in output:\n%s", kindDone, out.String())
+	}
+	if !sawDone {
+		t.Fatalf("expected a trailing done event, got:\n%s", out.String())
+	}
+}
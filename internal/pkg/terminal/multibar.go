@@ -0,0 +1,203 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// BarStyle controls the runes used to render a kind bar.
+type BarStyle struct {
+	Filler  rune
+	Tip     rune
+	Padding rune
+}
+
+// DefaultBarStyle is used by AddKindBar when no style has been set via
+// SetBarStyle.
+var DefaultBarStyle = BarStyle{Filler: '█', Tip: '>', Padding: '░'}
+
+// kindBarRow tracks a single kind's bar in the registry, in the order it
+// was added.
+type kindBarRow struct {
+	kind string
+	bar  *kindBar
+}
+
+// KindBar is a PBar scoped to a single kind, with an explicit Done
+// signal. Completion can't be inferred from current >= total alone: a
+// kind with zero resources to fetch has total == 0 and never receives
+// an Increment, yet is done as soon as the caller knows there's nothing
+// left to fetch.
+type KindBar interface {
+	PBar
+	// Done marks the bar as finished, so it scrolls off the in-flight
+	// block. Call it exactly once, once there's nothing left to fetch.
+	Done()
+}
+
+// kindBar is a PBar implementation safe for concurrent use, so the
+// goroutine fetching a kind's resources can report progress directly
+// without going through the UI's render loop.
+type kindBar struct {
+	mu       sync.Mutex
+	current  int
+	total    int
+	width    int
+	style    BarStyle
+	finished bool
+}
+
+func newKindBar(total int, style BarStyle) *kindBar {
+	return &kindBar{total: total, width: 10, style: style}
+}
+
+func (b *kindBar) Increment(n int) {
+	b.mu.Lock()
+	b.current += n
+	b.mu.Unlock()
+}
+
+func (b *kindBar) SetTotalIncrements(n int) {
+	b.mu.Lock()
+	b.total = n
+	b.mu.Unlock()
+}
+
+func (b *kindBar) SetWidth(w int) {
+	b.mu.Lock()
+	b.width = w
+	b.mu.Unlock()
+}
+
+// resizeToFit sets the bar's width so its full rendered line --
+// "<kind> <bar> <current>/<total>" -- fits within cols columns, given
+// kindLen, the length of its own kind-name prefix.
+func (b *kindBar) resizeToFit(cols, kindLen int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	countsWidth := len(strconv.Itoa(b.total))*2 + 1
+	width := cols - kindLen - 2 - countsWidth
+	if width < minBarWidth {
+		width = minBarWidth
+	}
+	b.width = width
+}
+
+func (b *kindBar) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.render()
+}
+
+func (b *kindBar) Done() {
+	b.mu.Lock()
+	b.finished = true
+	b.mu.Unlock()
+}
+
+// doneLocked reports whether the bar has been explicitly marked done.
+// It must be called with b.mu held.
+func (b *kindBar) doneLocked() bool {
+	return b.finished
+}
+
+// render builds the bar string. It must be called with b.mu held.
+func (b *kindBar) render() string {
+	width := b.width
+	if width < 1 {
+		width = 1
+	}
+	filled := 0
+	if b.total > 0 {
+		filled = width * b.current / b.total
+		if filled > width {
+			filled = width
+		}
+	}
+	bar := make([]rune, width)
+	for i := range bar {
+		switch {
+		case i < filled:
+			bar[i] = b.style.Filler
+		case i == filled:
+			bar[i] = b.style.Tip
+		default:
+			bar[i] = b.style.Padding
+		}
+	}
+	return string(bar)
+}
+
+// snapshot returns the bar's rendered line and whether it has finished.
+func (b *kindBar) snapshot(kind string) (line string, done bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return fmt.Sprintf("%s %s %d/%d", kind, b.render(), b.current, b.total), b.doneLocked()
+}
+
+// SetBarStyle sets the fill/tip/padding runes used by bars added via
+// AddKindBar. It has no effect on bars already added.
+func (u *UI) SetBarStyle(style BarStyle) {
+	u.barStyle = style
+}
+
+// AddKindBar registers a new, independently tracked progress bar for
+// kind and returns it so the goroutine fetching that kind's resources
+// can report progress concurrently with every other in-flight kind.
+func (u *UI) AddKindBar(kind string, total int) KindBar {
+	style := u.barStyle
+	if style == (BarStyle{}) {
+		style = DefaultBarStyle
+	}
+	bar := newKindBar(total, style)
+	if u.cols > 0 {
+		bar.resizeToFit(u.cols, len(kind))
+	}
+	u.barsMu.Lock()
+	u.bars = append(u.bars, &kindBarRow{kind: kind, bar: bar})
+	u.barsMu.Unlock()
+	return bar
+}
+
+// resizeKindBars resizes every registered per-kind bar to fit cols
+// columns, accounting for each row's own kind-name prefix, mirroring
+// what applyTermSize already does for the aggregate progress bar.
+func (u *UI) resizeKindBars(cols int) {
+	u.barsMu.Lock()
+	defer u.barsMu.Unlock()
+	for _, row := range u.bars {
+		row.bar.resizeToFit(cols, len(row.kind))
+	}
+}
+
+// kindBarLines returns the lines to render for in-flight kind bars,
+// capped at maxRows with a "+K more" overflow indicator, completed kinds
+// having scrolled off entirely.
+func (u *UI) kindBarLines(maxRows int) []string {
+	if maxRows < 0 {
+		maxRows = 0
+	}
+	u.barsMu.Lock()
+	defer u.barsMu.Unlock()
+	var inFlight []*kindBarRow
+	for _, row := range u.bars {
+		if _, done := row.bar.snapshot(row.kind); !done {
+			inFlight = append(inFlight, row)
+		}
+	}
+	overflow := 0
+	if len(inFlight) > maxRows {
+		overflow = len(inFlight) - maxRows
+		inFlight = inFlight[:maxRows]
+	}
+	lines := make([]string, 0, len(inFlight)+1)
+	for _, row := range inFlight {
+		line, _ := row.bar.snapshot(row.kind)
+		lines = append(lines, line)
+	}
+	if overflow > 0 {
+		lines = append(lines, fmt.Sprintf("  +%d more", overflow))
+	}
+	return lines
+}
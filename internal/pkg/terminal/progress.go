@@ -0,0 +1,105 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultMinUpdatePause is how often Start repaints by default. It can
+// be overridden with SetMinUpdatePause, e.g. interactive UIs pick
+// something closer to 16ms while CI/JSON modes can pick whole seconds.
+const defaultMinUpdatePause = 100 * time.Millisecond
+
+// kindEvent records a single ReportKindDone call, so consumers that
+// need one event per kind (the plain/JSON printers) can replay every
+// call made between two drains instead of only the latest one.
+type kindEvent struct {
+	kind      string
+	resources int
+}
+
+// progressCounter holds the aggregate progress state. The aggregate
+// counts are atomics and the rest is guarded by a mutex, so
+// ReportKindDone can be called concurrently from any goroutine fetching
+// a kind's resources, with no channel in between.
+type progressCounter struct {
+	totalKinds          atomic.Int64
+	processedKinds      atomic.Int64
+	totalResourcesFound atomic.Int64
+
+	mu                 sync.Mutex
+	lastProcessedKind  string
+	lastProcessedCount int
+	pending            []kindEvent
+}
+
+func (c *progressCounter) setTotalKinds(count int) {
+	c.totalKinds.Store(int64(count))
+}
+
+func (c *progressCounter) reportKindDone(kind string, resources int) {
+	c.processedKinds.Add(1)
+	c.totalResourcesFound.Add(int64(resources))
+	c.mu.Lock()
+	c.lastProcessedKind = kind
+	c.lastProcessedCount = resources
+	c.pending = append(c.pending, kindEvent{kind: kind, resources: resources})
+	c.mu.Unlock()
+}
+
+// snapshot returns a consistent-enough view of the counter for a single
+// repaint; it's not meant to be linearizable with reportKindDone.
+func (c *progressCounter) snapshot() (totalKinds, processedKinds, totalResourcesFound, lastProcessedCount int, lastProcessedKind string) {
+	c.mu.Lock()
+	lastProcessedKind = c.lastProcessedKind
+	lastProcessedCount = c.lastProcessedCount
+	c.mu.Unlock()
+	return int(c.totalKinds.Load()), int(c.processedKinds.Load()), int(c.totalResourcesFound.Load()), lastProcessedCount, lastProcessedKind
+}
+
+// drainPending removes and returns every kindEvent recorded since the
+// last drainPending call, in order, so a caller that needs to react to
+// each finished kind individually doesn't miss events that land between
+// two drains.
+func (c *progressCounter) drainPending() []kindEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) == 0 {
+		return nil
+	}
+	pending := c.pending
+	c.pending = nil
+	return pending
+}
+
+// ReportKindDone records that kind finished fetching resources. It is
+// safe to call from any goroutine, concurrently with other kinds and
+// with Start's repaint loop.
+func (u *UI) ReportKindDone(kind string, resources int) {
+	u.counter.reportKindDone(kind, resources)
+}
+
+// Finish signals that every kind has been reported and Start's repaint
+// loop should do a final render and return.
+func (u *UI) Finish() {
+	u.finishOnce.Do(func() { close(u.finished) })
+}
+
+// SetMinUpdatePause sets how often Start repaints. The default is
+// defaultMinUpdatePause.
+func (u *UI) SetMinUpdatePause(d time.Duration) {
+	u.minUpdatePause = d
+}
+
+// dumpProgress writes a single human-readable progress line straight to
+// stderr, bypassing the alternate screen, from a snapshot of the
+// counter. It's wired up to SIGUSR1/SIGINFO only while the interactive
+// UI runs; ModePlain and ModeJSON register no signal handler.
+func (u *UI) dumpProgress() {
+	totalKinds, processedKinds, totalResourcesFound, _, lastProcessedKind := u.counter.snapshot()
+	fmt.Fprintf(os.Stderr, "progress: %d/%d kinds, %d resources, current=%s\n",
+		processedKinds, totalKinds, totalResourcesFound, lastProcessedKind)
+}
@@ -0,0 +1,33 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchProgressDump registers a handler for SIGUSR1 and SIGINFO (the
+// BSD/macOS "print status" key, ctrl-T) that dumps a single progress
+// line to stderr on receipt. Call the returned stop func to deregister
+// it.
+func (u *UI) watchProgressDump() func() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGUSR1, syscall.SIGINFO)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sig:
+				u.dumpProgress()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
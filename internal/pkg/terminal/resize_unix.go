@@ -0,0 +1,36 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize returns a channel that receives a value whenever the
+// terminal size may have changed, driven by SIGWINCH. Call the returned
+// stop func when done watching to release the signal handler.
+func (u *UI) watchResize() (<-chan struct{}, func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+	resized := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sig:
+				select {
+				case resized <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return resized, func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
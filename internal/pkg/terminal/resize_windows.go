@@ -0,0 +1,32 @@
+//go:build windows
+
+package terminal
+
+import "time"
+
+// watchResize returns a channel that receives a value whenever the
+// terminal size may have changed. Windows has no SIGWINCH, so poll the
+// terminal size once a second instead. Call the returned stop func when
+// done watching to release the ticker.
+func (u *UI) watchResize() (<-chan struct{}, func()) {
+	ticker := time.NewTicker(time.Second)
+	resized := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case resized <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return resized, func() {
+		ticker.Stop()
+		close(done)
+	}
+}
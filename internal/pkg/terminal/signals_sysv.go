@@ -0,0 +1,32 @@
+//go:build !windows && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchProgressDump registers a handler for SIGUSR1, the signal restic
+// also uses to dump progress on Linux/Solaris, which have no SIGINFO.
+// Call the returned stop func to deregister it.
+func (u *UI) watchProgressDump() func() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGUSR1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sig:
+				u.dumpProgress()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
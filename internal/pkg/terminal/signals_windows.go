@@ -0,0 +1,9 @@
+//go:build windows
+
+package terminal
+
+// watchProgressDump is a no-op on Windows, which has neither SIGUSR1
+// nor SIGINFO.
+func (u *UI) watchProgressDump() func() {
+	return func() {}
+}
@@ -28,23 +28,52 @@ type PBar interface {
 	String() string
 }
 
+const (
+	// resizeOverhead reserves room on the progress bar line for the
+	// spinner, the "Fetched kinds:" label and the n/total counter.
+	resizeOverhead = 20
+	minBarWidth    = 1
+
+	// headerFooterLines is the number of fixed lines (the "Discovering
+	// kinds..." header and the aggregate summary/total footer) that
+	// surround the per-kind bar block.
+	headerFooterLines = 3
+	// defaultVisibleBars is how many kind bars to show when the
+	// terminal size isn't known yet.
+	defaultVisibleBars = 5
+)
+
 type UI struct {
-	getResourcesUpdates chan *GetResourcesUpdate
-	nbExecs, nbTputs    int
-	progressBar         PBar
-	termInfo            TermInfo
-	termInfoCache       map[string]string
-	totalKinds          chan int
-	writer              *bufio.Writer
+	nbExecs, nbTputs int
+	progressBar      PBar
+	termInfo         TermInfo
+	termInfoCache    map[string]string
+	writer           *bufio.Writer
+	lines, cols      int
+	barsMu           sync.Mutex
+	bars             []*kindBarRow
+	barStyle         BarStyle
+	mode             Mode
+
+	totalKindsSet  chan struct{}
+	totalKindsOnce sync.Once
+
+	counter        progressCounter
+	minUpdatePause time.Duration
+	finished       chan struct{}
+	finishOnce     sync.Once
 }
 
 func NewUI(progressBar PBar, termInfo TermInfo, writer io.Writer) *UI {
 	return &UI{
-		progressBar:   progressBar,
-		termInfo:      termInfo,
-		termInfoCache: make(map[string]string),
-		totalKinds:    make(chan int, 1),
-		writer:        bufio.NewWriter(writer),
+		progressBar:    progressBar,
+		termInfo:       termInfo,
+		termInfoCache:  make(map[string]string),
+		mode:           detectMode(writer),
+		writer:         bufio.NewWriter(writer),
+		totalKindsSet:  make(chan struct{}),
+		finished:       make(chan struct{}),
+		minUpdatePause: defaultMinUpdatePause,
 	}
 }
 
@@ -110,6 +139,13 @@ func (u *UI) Println(a ...interface{}) {
 	fmt.Fprintln(u.writer, a...)
 }
 
+// cursorTo moves the cursor to the given row and column using the
+// parameterized "cup" terminfo capability, rather than a literal
+// sequence for a single fixed position.
+func (u *UI) cursorTo(row, col int) {
+	u.tput(fmt.Sprintf("cup %d %d", row, col))
+}
+
 func (u *UI) moveCursorUp(lines int) {
 	for i := 0; i < lines; i++ {
 		u.tput("cuu1")
@@ -133,72 +169,139 @@ func (u *UI) flush() error {
 	return u.writer.Flush()
 }
 
-func (u *UI) SetTotalKinds(count int) chan<- *GetResourcesUpdate {
-	u.totalKinds <- count
-	u.getResourcesUpdates = make(chan *GetResourcesUpdate, count)
-	return u.getResourcesUpdates
+// SetTotalKinds records how many kinds will be reported via
+// ReportKindDone. It must be called exactly once, before the first call
+// to ReportKindDone. The count itself lives in the counter (guarded the
+// same way as the rest of the progress state) so it can be read safely
+// from the SIGUSR1/SIGINFO handler goroutine, not just the render loop.
+func (u *UI) SetTotalKinds(count int) {
+	u.counter.setTotalKinds(count)
+	u.totalKindsOnce.Do(func() { close(u.totalKindsSet) })
+}
+
+// ellipsize truncates s to at most max runes, replacing the final rune
+// with "…" when truncation occurs, so it fits a fixed-width column.
+func ellipsize(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	if max == 1 {
+		return "…"
+	}
+	return string(r[:max-1]) + "…"
+}
+
+// applyTermSize re-queries the terminal size and resizes the progress
+// bar to fit, reserving resizeOverhead columns for the surrounding text.
+func (u *UI) applyTermSize() {
+	lines, cols, err := u.getTermSize()
+	if err != nil {
+		return
+	}
+	u.lines, u.cols = lines, cols
+	width := cols - resizeOverhead
+	if width < minBarWidth {
+		width = minBarWidth
+	}
+	u.progressBar.SetWidth(width)
+	u.resizeKindBars(cols)
 }
 
 func (u *UI) Start(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done() // important: do this last
+	if u.mode != ModeInteractive {
+		u.startReporting(ctx)
+		return
+	}
+	u.startInteractive(ctx)
+}
+
+// startInteractive renders the full-screen, alternate-screen UI with
+// the spinner, per-kind bars and resize handling. It is only used in
+// ModeInteractive.
+func (u *UI) startInteractive(ctx context.Context) {
 	defer u.flush()
 	defer u.showCursor()
 	defer u.exitAlternateScreen()
 	u.hideCursor()
 	u.enterAlternateScreen()
-	// lines, cols, _ := u.getTermSize()
-	// cols, err := u.GetTermCols()
-	// if err != nil {
-	// 	cols = 30
-	// }
-	// windowSizeChange := make(chan os.Signal, 1)
-	// signal.Notify(windowSizeChange, syscall.SIGWINCH)
+	windowSizeChange, stopResize := u.watchResize()
+	defer stopResize()
+	stopProgressDump := u.watchProgressDump()
+	defer stopProgressDump()
 	u.Printf("Discovering kinds...")
 	u.flush()
-	var totalKinds int
 	select {
 	case <-ctx.Done():
 		return
-	case totalKinds = <-u.totalKinds:
+	case <-u.totalKindsSet:
 	}
+	totalKinds, _, _, _, _ := u.counter.snapshot()
 	u.Printf(" found %d.\n", totalKinds)
-	u.progressBar.SetWidth(10)
+	u.applyTermSize()
 	u.progressBar.SetTotalIncrements(totalKinds)
 	spinner := NewSpinner(100 * time.Millisecond)
-	var processedKinds int
-	var totalResourcesFound int
-	var lastProcessedKind string
 	formatWidth := len(strconv.Itoa(totalKinds))
 	eraseLine := u.queryTerminfo("el")
+	redraw := time.NewTicker(u.minUpdatePause)
+	defer redraw.Stop()
 	var progressLines []string
+	var prevProcessed int
+	var prevLineCount int
 	for {
-		u.tput("cup 0 0")
-		progressLines = []string{
-			fmt.Sprintf("Discovering kinds... found %d.\n", totalKinds),
-			fmt.Sprintf("\r%s Fetched kinds: %s %*d/%d\n",
-				spinner, u.progressBar.String(), formatWidth, processedKinds, totalKinds),
-			fmt.Sprintf("Getting %s\n", lastProcessedKind),
-			fmt.Sprintf("Total resources found: %4d", totalResourcesFound),
+		_, processedKinds, totalResourcesFound, _, lastProcessedKind := u.counter.snapshot()
+		u.progressBar.Increment(processedKinds - prevProcessed)
+		prevProcessed = processedKinds
+
+		u.cursorTo(0, 0)
+		if u.lines > 0 && u.lines < 4 {
+			prefix := fmt.Sprintf("\r%s %*d/%d kinds, %d resources, current=",
+				spinner, formatWidth, processedKinds, totalKinds, totalResourcesFound)
+			kindCol := u.cols - len(prefix)
+			progressLines = []string{
+				prefix + ellipsize(lastProcessedKind, kindCol),
+			}
+		} else {
+			maxBarRows := defaultVisibleBars
+			if u.lines > 0 {
+				maxBarRows = u.lines - headerFooterLines
+			}
+			progressLines = append([]string{
+				fmt.Sprintf("Discovering kinds... found %d.\n", totalKinds),
+			}, u.kindBarLines(maxBarRows)...)
+			for i := range progressLines[1:] {
+				progressLines[i+1] += "\n"
+			}
+			progressLines = append(progressLines,
+				fmt.Sprintf("\r%s Fetched kinds: %s %*d/%d\n",
+					spinner, u.progressBar.String(), formatWidth, processedKinds, totalKinds),
+				fmt.Sprintf("Total resources found: %4d", totalResourcesFound),
+			)
 		}
 		u.Print(strings.Join(progressLines, eraseLine))
+		// The frame can shrink (kinds scrolling off, a resize shrinking
+		// maxBarRows, switching into compact mode): clear whatever of the
+		// previous, taller frame is left below so it doesn't linger on
+		// screen. The next iteration's cursorTo(0, 0) repositions us for
+		// the following redraw regardless of where this leaves the cursor.
+		for extra := prevLineCount - len(progressLines); extra > 0; extra-- {
+			u.Print("\n")
+			u.eraseCurrentLine()
+		}
+		prevLineCount = len(progressLines)
 		u.flush()
 		select {
 		case <-ctx.Done():
 			return
-		// case <-windowSizeChange:
-		// 	newCols, err := u.termInfo.QueryInt("cols")
-		// 	if err == nil {
-		// 		cols = newCols
-		// 		// progressBar.SetWidth(cols - 10)
-		// 	}
-		case getResourcesUpdate, more := <-u.getResourcesUpdates:
-			if !more {
-				return
-			}
-			u.progressBar.Increment(1)
-			lastProcessedKind = getResourcesUpdate.Kind
-			processedKinds++
-			totalResourcesFound += getResourcesUpdate.Resources
+		case <-u.finished:
+			return
+		case <-windowSizeChange:
+			u.applyTermSize()
+		case <-redraw.C:
 		case <-spinner.Tick:
 			spinner.Spin()
 		}
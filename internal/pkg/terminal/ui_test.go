@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"bytes"
+	"testing"
+)
+
+// fakeTermInfo implements TermInfo and returns the next configured value
+// each time a given capname is queried, repeating the last one once they
+// run out, so tests can simulate a resize happening between two queries.
+type fakeTermInfo struct {
+	queryInts map[string][]int
+	callCount map[string]int
+}
+
+func newFakeTermInfo() *fakeTermInfo {
+	return &fakeTermInfo{
+		queryInts: make(map[string][]int),
+		callCount: make(map[string]int),
+	}
+}
+
+func (f *fakeTermInfo) Query(capname ...string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeTermInfo) QueryInt(capname string) (int, error) {
+	values := f.queryInts[capname]
+	i := f.callCount[capname]
+	f.callCount[capname]++
+	if i >= len(values) {
+		i = len(values) - 1
+	}
+	return values[i], nil
+}
+
+func newTestUI() (*UI, *fakeTermInfo) {
+	termInfo := newFakeTermInfo()
+	bar := &noopBar{}
+	ui := NewUI(bar, termInfo, &bytes.Buffer{})
+	return ui, termInfo
+}
+
+type noopBar struct {
+	width int
+}
+
+func (b *noopBar) Increment(int)          {}
+func (b *noopBar) SetTotalIncrements(int) {}
+func (b *noopBar) SetWidth(w int)         { b.width = w }
+func (b *noopBar) String() string         { return "" }
+
+func TestApplyTermSizeReflectsResize(t *testing.T) {
+	ui, termInfo := newTestUI()
+	termInfo.queryInts["lines"] = []int{40, 20}
+	termInfo.queryInts["cols"] = []int{100, 40}
+
+	ui.applyTermSize()
+	if ui.lines != 40 || ui.cols != 100 {
+		t.Fatalf("expected 40x100, got %dx%d", ui.lines, ui.cols)
+	}
+	bar := ui.progressBar.(*noopBar)
+	if bar.width != 100-resizeOverhead {
+		t.Fatalf("expected bar width %d, got %d", 100-resizeOverhead, bar.width)
+	}
+
+	ui.applyTermSize()
+	if ui.lines != 20 || ui.cols != 40 {
+		t.Fatalf("expected resize to 20x40, got %dx%d", ui.lines, ui.cols)
+	}
+	if bar.width != 40-resizeOverhead {
+		t.Fatalf("expected bar width %d, got %d", 40-resizeOverhead, bar.width)
+	}
+}
+
+func TestApplyTermSizeClampsNarrowWidth(t *testing.T) {
+	ui, termInfo := newTestUI()
+	termInfo.queryInts["lines"] = []int{24}
+	termInfo.queryInts["cols"] = []int{5}
+
+	ui.applyTermSize()
+	bar := ui.progressBar.(*noopBar)
+	if bar.width != minBarWidth {
+		t.Fatalf("expected clamped width %d, got %d", minBarWidth, bar.width)
+	}
+}
+
+func TestEllipsize(t *testing.T) {
+	cases := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"deployments", 20, "deployments"},
+		{"deployments", 5, "depl…"},
+		{"deployments", 1, "…"},
+		{"deployments", 0, ""},
+	}
+	for _, c := range cases {
+		if got := ellipsize(c.in, c.max); got != c.want {
+			t.Errorf("ellipsize(%q, %d) = %q, want %q", c.in, c.max, got, c.want)
+		}
+	}
+}